@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// priorityClassDataSourceMetadataSchema returns the `metadata` block for the
+// kubernetes_priority_class data source. Unlike the resource's metadataSchema,
+// `name` is Required (a lookup without a name is meaningless) and there is no
+// `generate_name`, which only makes sense for objects Terraform creates.
+func priorityClassDataSourceMetadataSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"annotations": {
+					Type:        schema.TypeMap,
+					Description: "An unstructured key value map stored with the priority class that may be used to store arbitrary metadata.",
+					Computed:    true,
+				},
+				"labels": {
+					Type:        schema.TypeMap,
+					Description: "Map of string keys and values that can be used to organize and categorize the priority class.",
+					Computed:    true,
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Description: "Name of the priority class.",
+					Required:    true,
+				},
+				"generation": {
+					Type:        schema.TypeInt,
+					Description: "A sequence number representing a specific generation of the desired state.",
+					Computed:    true,
+				},
+				"resource_version": {
+					Type:        schema.TypeString,
+					Description: "An opaque value that represents the internal version of this priority class that can be used by clients to determine when priority class has changed.",
+					Computed:    true,
+				},
+				"self_link": {
+					Type:        schema.TypeString,
+					Description: "A URL representing this priority class.",
+					Computed:    true,
+				},
+				"uid": {
+					Type:        schema.TypeString,
+					Description: "The unique in time and space value for this priority class.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesPriorityClass() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesPriorityClassRead,
+		Schema: map[string]*schema.Schema{
+			"metadata": priorityClassDataSourceMetadataSchema(),
+			"description": {
+				Type:        schema.TypeString,
+				Description: "An arbitrary string that usually provides guidelines on when this priority class should be used.",
+				Computed:    true,
+			},
+			"global_default": {
+				Type:        schema.TypeBool,
+				Description: "Specifies whether this PriorityClass should be considered as the default priority for pods that do not have any priority class.",
+				Computed:    true,
+			},
+			"value": {
+				Type:        schema.TypeInt,
+				Description: "The value of this priority class. This is the actual priority that pods receive when they have the name of this class in their pod spec.",
+				Computed:    true,
+			},
+			"preemption_policy": {
+				Type:        schema.TypeString,
+				Description: "PreemptionPolicy is the Policy for preempting pods with lower priority. One of `Never`, `PreemptLowerPriority`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesPriorityClassRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	name := metadata.Name
+
+	log.Printf("[INFO] Reading priority class %s", name)
+	pc, err := getPriorityClass(conn, name)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Received priority class: %#v", pc)
+
+	err = d.Set("metadata", flattenMetadata(withoutExpiryAnnotation(pc.ObjectMeta), d))
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("value", pc.Value)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("description", pc.Description)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("global_default", pc.GlobalDefault)
+	if err != nil {
+		return err
+	}
+
+	if pc.PreemptionPolicy != nil {
+		err = d.Set("preemption_policy", *pc.PreemptionPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(pc.buildId())
+
+	return nil
+}
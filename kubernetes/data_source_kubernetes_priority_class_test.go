@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDataSourceKubernetesPriorityClassMetadataSchema(t *testing.T) {
+	metadataSchema := dataSourceKubernetesPriorityClass().Schema["metadata"]
+	if !metadataSchema.Required {
+		t.Error("expected metadata to be Required on the data source, since a lookup without a name is meaningless")
+	}
+
+	elem, ok := metadataSchema.Elem.(*schema.Resource)
+	if !ok {
+		t.Fatalf("expected metadata.Elem to be a *schema.Resource, got %T", metadataSchema.Elem)
+	}
+
+	nameSchema, ok := elem.Schema["name"]
+	if !ok {
+		t.Fatal("expected metadata to have a name attribute")
+	}
+	if !nameSchema.Required {
+		t.Error("expected metadata.name to be Required on the data source")
+	}
+
+	if _, ok := elem.Schema["generate_name"]; ok {
+		t.Error("generate_name is meaningless for a read-only data source and should not be present")
+	}
+}
@@ -0,0 +1,94 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// expiryAnnotationKey is stamped on resources that support the `expires_at`/
+// `ttl` attributes so that Read can later tell whether the object has
+// outlived its intended lifetime.
+const expiryAnnotationKey = "terraform.kubernetes.io/expires-at"
+
+// expiryFromSchema computes the absolute expiry time a resource should carry
+// based on its `expires_at`/`ttl` attributes. Exactly one of the two may be
+// set; if neither is set, expiryFromSchema returns a nil time and no error,
+// meaning the resource never expires.
+func expiryFromSchema(d *schema.ResourceData) (*time.Time, error) {
+	expiresAt, hasExpiresAt := d.GetOk("expires_at")
+	ttl, hasTTL := d.GetOk("ttl")
+
+	switch {
+	case hasExpiresAt:
+		t, err := time.Parse(time.RFC3339, expiresAt.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse expires_at %q: %s", expiresAt, err)
+		}
+		return &t, nil
+	case hasTTL:
+		duration, err := time.ParseDuration(ttl.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse ttl %q: %s", ttl, err)
+		}
+		t := time.Now().Add(duration)
+		return &t, nil
+	default:
+		return nil, nil
+	}
+}
+
+// applyExpiryAnnotation stamps the computed expiry, if any, onto the given
+// ObjectMeta's annotations so that it round-trips through etcd along with
+// the rest of the object.
+func applyExpiryAnnotation(metadata *meta_v1.ObjectMeta, d *schema.ResourceData) error {
+	expiry, err := expiryFromSchema(d)
+	if err != nil {
+		return err
+	}
+	if expiry == nil {
+		return nil
+	}
+	if metadata.Annotations == nil {
+		metadata.Annotations = map[string]string{}
+	}
+	metadata.Annotations[expiryAnnotationKey] = expiry.Format(time.RFC3339)
+	return nil
+}
+
+// isExpired reports whether the object carrying the given annotations has
+// passed its recorded expiry. Objects without the annotation never expire.
+func isExpired(annotations map[string]string) bool {
+	value, ok := annotations[expiryAnnotationKey]
+	if !ok {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(expiry)
+}
+
+// withoutExpiryAnnotation returns a copy of the given ObjectMeta with the
+// internal expiry annotation removed. It must be used wherever an object's
+// metadata is surfaced back into `metadata.0.annotations`: the annotation is
+// stamped by the provider, not the user's config, so leaving it visible
+// there would show up as drift on the next plan and patchMetadata would emit
+// a remove op for it, undoing the TTL on the very next apply.
+func withoutExpiryAnnotation(in meta_v1.ObjectMeta) meta_v1.ObjectMeta {
+	if _, ok := in.Annotations[expiryAnnotationKey]; !ok {
+		return in
+	}
+	out := in
+	out.Annotations = make(map[string]string, len(in.Annotations)-1)
+	for k, v := range in.Annotations {
+		if k == expiryAnnotationKey {
+			continue
+		}
+		out.Annotations[k] = v
+	}
+	return out
+}
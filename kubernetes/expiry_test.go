@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateRFC3339Time(t *testing.T) {
+	if _, errs := validateRFC3339Time("2030-01-02T15:04:05Z", "expires_at"); len(errs) != 0 {
+		t.Errorf("expected a valid RFC3339 timestamp to pass, got: %v", errs)
+	}
+	if _, errs := validateRFC3339Time("not-a-timestamp", "expires_at"); len(errs) == 0 {
+		t.Error("expected an invalid timestamp to fail validation")
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	if _, errs := validateDuration("24h", "ttl"); len(errs) != 0 {
+		t.Errorf("expected a valid duration to pass, got: %v", errs)
+	}
+	if _, errs := validateDuration("not-a-duration", "ttl"); len(errs) == 0 {
+		t.Error("expected an invalid duration to fail validation")
+	}
+}
+
+func TestExpiryFromSchema(t *testing.T) {
+	schemaMap := resourceKubernetesPriorityClass().Schema
+
+	expiresAt := "2030-06-15T00:00:00Z"
+	d := schema.TestResourceDataRaw(t, schemaMap, map[string]interface{}{
+		"expires_at": expiresAt,
+	})
+	expiry, err := expiryFromSchema(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expiry == nil || expiry.Format(time.RFC3339) != expiresAt {
+		t.Fatalf("expected expiry %s, got %v", expiresAt, expiry)
+	}
+
+	d = schema.TestResourceDataRaw(t, schemaMap, map[string]interface{}{
+		"ttl": "1h",
+	})
+	expiry, err = expiryFromSchema(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expiry == nil {
+		t.Fatal("expected a computed expiry from ttl, got nil")
+	}
+	if !expiry.After(time.Now()) {
+		t.Fatalf("expected expiry derived from ttl to be in the future, got %s", expiry)
+	}
+
+	d = schema.TestResourceDataRaw(t, schemaMap, map[string]interface{}{})
+	expiry, err = expiryFromSchema(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expiry != nil {
+		t.Fatalf("expected no expiry when neither attribute is set, got %s", expiry)
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	future := map[string]string{expiryAnnotationKey: time.Now().Add(time.Hour).Format(time.RFC3339)}
+	if isExpired(future) {
+		t.Error("expected a future expiry to not be expired")
+	}
+
+	past := map[string]string{expiryAnnotationKey: time.Now().Add(-time.Hour).Format(time.RFC3339)}
+	if !isExpired(past) {
+		t.Error("expected a past expiry to be expired")
+	}
+
+	if isExpired(map[string]string{}) {
+		t.Error("expected an object without the annotation to never be expired")
+	}
+}
+
+func TestWithoutExpiryAnnotation(t *testing.T) {
+	in := meta_v1.ObjectMeta{
+		Name: "some-priority-class",
+		Annotations: map[string]string{
+			expiryAnnotationKey: time.Now().Format(time.RFC3339),
+			"user-provided":     "keep-me",
+		},
+	}
+
+	out := withoutExpiryAnnotation(in)
+	if _, ok := out.Annotations[expiryAnnotationKey]; ok {
+		t.Error("expected the internal expiry annotation to be stripped")
+	}
+	if out.Annotations["user-provided"] != "keep-me" {
+		t.Error("expected user-provided annotations to be preserved")
+	}
+
+	// The original must be untouched.
+	if _, ok := in.Annotations[expiryAnnotationKey]; !ok {
+		t.Error("withoutExpiryAnnotation must not mutate its input")
+	}
+
+	noAnnotations := meta_v1.ObjectMeta{Name: "clean"}
+	if out := withoutExpiryAnnotation(noAnnotations); out.Annotations != nil {
+		t.Error("expected a nil annotations map to stay nil when there's nothing to strip")
+	}
+}
@@ -3,17 +3,40 @@ package kubernetes
 import (
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
-	api "k8s.io/api/scheduling/v1beta1"
+	"github.com/hashicorp/terraform/helper/validation"
+	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	pkgApi "k8s.io/apimachinery/pkg/types"
 	kubernetes "k8s.io/client-go/kubernetes"
 )
 
+// minMutablePreemptionPolicyMinor is the Kubernetes minor version from which
+// the API server allows `preemptionPolicy` to be changed on an existing
+// PriorityClass. Older clusters reject the mutation, so the resource must
+// fall back to ForceNew in that case. This is a property of the apiserver's
+// validation, not of which scheduling.k8s.io version is being negotiated
+// (v1 itself has been GA since 1.14, long before mutation was allowed).
+const minMutablePreemptionPolicyMinor = 22
+
+// preemptionPolicyMutable reports whether the cluster the clientset is
+// talking to accepts in-place updates of a PriorityClass' preemptionPolicy.
+func preemptionPolicyMutable(conn *kubernetes.Clientset) (bool, error) {
+	serverVersion, err := conn.Discovery().ServerVersion()
+	if err != nil {
+		return false, fmt.Errorf("Failed to determine server version: %s", err)
+	}
+	minor, err := strconv.Atoi(strings.TrimRight(serverVersion.Minor, "+"))
+	if err != nil {
+		return false, fmt.Errorf("Failed to parse server minor version %q: %s", serverVersion.Minor, err)
+	}
+	return minor >= minMutablePreemptionPolicyMinor, nil
+}
+
 func resourceKubernetesPriorityClass() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceKubernetesPriorityClassCreate,
@@ -24,6 +47,20 @@ func resourceKubernetesPriorityClass() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			if diff.Id() == "" || !diff.HasChange("preemption_policy") {
+				return nil
+			}
+			conn := meta.(*kubernetes.Clientset)
+			mutable, err := preemptionPolicyMutable(conn)
+			if err != nil {
+				return err
+			}
+			if !mutable {
+				return diff.ForceNew("preemption_policy")
+			}
+			return nil
+		},
 
 		Schema: map[string]*schema.Schema{
 			"metadata": metadataSchema("priority class", true),
@@ -44,44 +81,102 @@ func resourceKubernetesPriorityClass() *schema.Resource {
 				Description: "The value of this priority class. This is the actual priority that pods receive when they have the name of this class in their pod spec.",
 				Required:    true,
 			},
+			"preemption_policy": {
+				Type:        schema.TypeString,
+				Description: "PreemptionPolicy is the Policy for preempting pods with lower priority. One of `Never`, `PreemptLowerPriority`. Defaults to `PreemptLowerPriority` if unset.",
+				Optional:    true,
+				Computed:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(core_v1.PreemptLowerPriority),
+					string(core_v1.PreemptNever),
+				}, false),
+			},
+			"expires_at": {
+				Type:          schema.TypeString,
+				Description:   "An RFC3339 timestamp at which this priority class is considered expired. Once that time has passed, the next `terraform apply`/`refresh` treats the class as gone and deletes it from the cluster. Conflicts with `ttl`.",
+				Optional:      true,
+				ValidateFunc:  validateRFC3339Time,
+				ConflictsWith: []string{"ttl"},
+			},
+			"ttl": {
+				Type:          schema.TypeString,
+				Description:   "A duration (e.g. `24h`, `30m`) after creation at which this priority class is considered expired and is deleted from the cluster. Changing `ttl` on an existing class restarts the duration from the time of that update. Conflicts with `expires_at`.",
+				Optional:      true,
+				ValidateFunc:  validateDuration,
+				ConflictsWith: []string{"expires_at"},
+			},
 		},
 	}
 }
 
+func validateRFC3339Time(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an RFC3339 timestamp: %s", k, err))
+	}
+	return
+}
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid duration: %s", k, err))
+	}
+	return
+}
+
 func resourceKubernetesPriorityClassCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetes.Clientset)
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	value := d.Get("value").(int32)
+	value := int32(d.Get("value").(int))
 	description := d.Get("description").(string)
 	globalDefault := d.Get("global_default").(bool)
 
-	priorityClass := api.PriorityClass{
+	newPriorityClass := priorityClass{
 		ObjectMeta:    metadata,
 		Description:   description,
 		GlobalDefault: globalDefault,
 		Value:         value,
 	}
 
-	log.Printf("[INFO] Creating new priority class: %#v", priorityClass)
-	out, err := conn.Scheduling().PriorityClasses().Create(&priorityClass)
+	if v, ok := d.GetOk("preemption_policy"); ok {
+		version, err := highestSchedulingAPIVersion(conn)
+		if err != nil {
+			return err
+		}
+		if version == schedulingV1alpha1 {
+			return fmt.Errorf("preemption_policy is set, but the connected cluster only serves scheduling.k8s.io/v1alpha1, which has no preemptionPolicy field")
+		}
+		policy := v.(string)
+		newPriorityClass.PreemptionPolicy = &policy
+	}
+
+	if err := applyExpiryAnnotation(&newPriorityClass.ObjectMeta, d); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating new priority class: %#v", newPriorityClass)
+	out, err := createPriorityClass(conn, newPriorityClass)
 	if err != nil {
 		return fmt.Errorf("Failed to create priority class: %s", err)
 	}
 	log.Printf("[INFO] Submitted new priority class: %#v", out)
-	d.SetId(buildId(out.ObjectMeta))
+	d.SetId(out.buildId())
 
+	expectedAnnotation := newPriorityClass.ObjectMeta.Annotations[expiryAnnotationKey]
 	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
-		createdPriorityClass, err := conn.Scheduling().PriorityClasses().Get(out.Name, meta_v1.GetOptions{})
+		createdPriorityClass, err := getPriorityClass(conn, out.ObjectMeta.Name)
 		if err != nil {
 			return resource.NonRetryableError(err)
 		}
-		if createdPriorityClass.Value == priorityClass.Value {
-			return nil
+		if createdPriorityClass.Value != newPriorityClass.Value {
+			err = fmt.Errorf("Priority class doesn't match after creation.\nExpected: %#v\nGiven: %#v",
+				newPriorityClass.Value, createdPriorityClass.Value)
+			return resource.RetryableError(err)
 		}
-		err = fmt.Errorf("Priority class doesn't match after creation.\nExpected: %#v\nGiven: %#v",
-			createdPriorityClass.Value, priorityClass.Value)
-		return resource.RetryableError(err)
+		if expectedAnnotation != "" && createdPriorityClass.ObjectMeta.Annotations[expiryAnnotationKey] != expectedAnnotation {
+			return resource.RetryableError(fmt.Errorf("Priority class expiry annotation has not round-tripped through etcd yet"))
+		}
+		return nil
 	})
 	if err != nil {
 		return err
@@ -95,41 +190,57 @@ func resourceKubernetesPriorityClassRead(d *schema.ResourceData, meta interface{
 	name := d.Id()
 
 	log.Printf("[INFO] Reading priority class %s", name)
-	priorityClass, err := conn.Scheduling().PriorityClasses().Get(name, meta_v1.GetOptions{})
+	pc, err := getPriorityClass(conn, name)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
-	log.Printf("[INFO] Received priority class: %#v", priorityClass)
+	log.Printf("[INFO] Received priority class: %#v", pc)
+
+	if isExpired(pc.ObjectMeta.Annotations) {
+		log.Printf("[INFO] Priority class %s has expired, deleting it eagerly", name)
+		if err := deletePriorityClass(conn, name); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		d.SetId("")
+		return nil
+	}
 
 	// This is to work around K8S bug
 	// See https://github.com/kubernetes/kubernetes/issues/44539
-	if priorityClass.ObjectMeta.GenerateName == "" {
+	if pc.ObjectMeta.GenerateName == "" {
 		if v, ok := d.GetOk("metadata.0.generate_name"); ok {
-			priorityClass.ObjectMeta.GenerateName = v.(string)
+			pc.ObjectMeta.GenerateName = v.(string)
 		}
 	}
 
-	err = d.Set("metadata", flattenMetadata(priorityClass.ObjectMeta, d))
+	err = d.Set("metadata", flattenMetadata(withoutExpiryAnnotation(pc.ObjectMeta), d))
 	if err != nil {
 		return err
 	}
 
-	err = d.Set("value", priorityClass.Value)
+	err = d.Set("value", pc.Value)
 	if err != nil {
 		return err
 	}
 
-	err = d.Set("description", priorityClass.Description)
+	err = d.Set("description", pc.Description)
 	if err != nil {
 		return err
 	}
 
-	err = d.Set("global_default", priorityClass.GlobalDefault)
+	err = d.Set("global_default", pc.GlobalDefault)
 	if err != nil {
 		return err
 	}
 
+	if pc.PreemptionPolicy != nil {
+		err = d.Set("preemption_policy", *pc.PreemptionPolicy)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -140,7 +251,7 @@ func resourceKubernetesPriorityClassUpdate(d *schema.ResourceData, meta interfac
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
 	if d.HasChange("value") {
-		value := d.Get("value").(int32)
+		value := int32(d.Get("value").(int))
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/value",
 			Value: value,
@@ -156,24 +267,67 @@ func resourceKubernetesPriorityClassUpdate(d *schema.ResourceData, meta interfac
 	}
 
 	if d.HasChange("global_default") {
-		globalDefault := d.Get("global_default").(string)
+		globalDefault := d.Get("global_default").(bool)
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/globalDefault",
 			Value: globalDefault,
 		})
 	}
 
+	if d.HasChange("preemption_policy") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/preemptionPolicy",
+			Value: d.Get("preemption_policy").(string),
+		})
+	}
+
+	if d.HasChange("expires_at") || d.HasChange("ttl") {
+		expiry, err := expiryFromSchema(d)
+		if err != nil {
+			return err
+		}
+
+		// A JSON-Patch "add" to a single annotation key is rejected by the
+		// apiserver if `/metadata/annotations` itself doesn't exist yet (a
+		// nil/empty annotations map is dropped from the stored object by
+		// `omitempty`). Find out from the live object whether that's the
+		// case and add the whole map instead of just our key when it is.
+		current, err := getPriorityClass(conn, name)
+		if err != nil {
+			return err
+		}
+
+		if expiry != nil {
+			value := expiry.Format(time.RFC3339)
+			if len(current.ObjectMeta.Annotations) == 0 {
+				ops = append(ops, &AddOperation{
+					Path:  "/metadata/annotations",
+					Value: map[string]string{expiryAnnotationKey: value},
+				})
+			} else {
+				ops = append(ops, &AddOperation{
+					Path:  "/metadata/annotations/" + strings.Replace(expiryAnnotationKey, "/", "~1", -1),
+					Value: value,
+				})
+			}
+		} else {
+			ops = append(ops, &RemoveOperation{
+				Path: "/metadata/annotations/" + strings.Replace(expiryAnnotationKey, "/", "~1", -1),
+			})
+		}
+	}
+
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
 	}
 	log.Printf("[INFO] Updating priority class %q: %v", name, string(data))
-	out, err := conn.Scheduling().PriorityClasses().Patch(name, pkgApi.JSONPatchType, data)
+	out, err := patchPriorityClass(conn, name, data)
 	if err != nil {
 		return fmt.Errorf("Failed to update priority class: %s", err)
 	}
 	log.Printf("[INFO] Submitted updated priority class: %#v", out)
-	d.SetId(buildId(out.ObjectMeta))
+	d.SetId(out.buildId())
 
 	return resourceKubernetesPriorityClassRead(d, meta)
 }
@@ -183,7 +337,7 @@ func resourceKubernetesPriorityClassDelete(d *schema.ResourceData, meta interfac
 	name := d.Id()
 
 	log.Printf("[INFO] Deleting priority class: %#v", name)
-	err := conn.Scheduling().PriorityClasses().Delete(name, &meta_v1.DeleteOptions{})
+	err := deletePriorityClass(conn, name)
 	if err != nil {
 		return err
 	}
@@ -199,7 +353,7 @@ func resourceKubernetesPriorityClassExists(d *schema.ResourceData, meta interfac
 	name := d.Id()
 
 	log.Printf("[INFO] Checking priority class %s", name)
-	_, err := conn.Scheduling().PriorityClasses().Get(name, meta_v1.GetOptions{})
+	_, err := getPriorityClass(conn, name)
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
 			return false, nil
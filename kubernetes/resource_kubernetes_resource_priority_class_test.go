@@ -0,0 +1,21 @@
+package kubernetes
+
+import "testing"
+
+func TestResourceKubernetesPriorityClassPreemptionPolicyValidation(t *testing.T) {
+	validateFunc := resourceKubernetesPriorityClass().Schema["preemption_policy"].ValidateFunc
+
+	for _, value := range []string{"Never", "PreemptLowerPriority"} {
+		_, errs := validateFunc(value, "preemption_policy")
+		if len(errs) != 0 {
+			t.Errorf("expected %q to be valid, got errors: %v", value, errs)
+		}
+	}
+
+	for _, value := range []string{"", "never", "Preempt"} {
+		_, errs := validateFunc(value, "preemption_policy")
+		if len(errs) == 0 {
+			t.Errorf("expected %q to be invalid, got no errors", value)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sync"
+
+	core_v1 "k8s.io/api/core/v1"
+	api_v1 "k8s.io/api/scheduling/v1"
+	api_v1alpha1 "k8s.io/api/scheduling/v1alpha1"
+	api_v1beta1 "k8s.io/api/scheduling/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/client-go/kubernetes"
+)
+
+// schedulingAPIVersion identifies which generation of the scheduling.k8s.io
+// API group a cluster serves for PriorityClass resources.
+type schedulingAPIVersion string
+
+const (
+	schedulingV1       schedulingAPIVersion = "v1"
+	schedulingV1beta1  schedulingAPIVersion = "v1beta1"
+	schedulingV1alpha1 schedulingAPIVersion = "v1alpha1"
+)
+
+// schedulingVersionCache memoizes the result of the discovery lookup below so
+// that it only hits the API server once per provider instance (clientset),
+// rather than on every CRUD operation against kubernetes_priority_class.
+var (
+	schedulingVersionCacheMu sync.Mutex
+	schedulingVersionCache   = map[*kubernetes.Clientset]schedulingAPIVersion{}
+)
+
+// highestSchedulingAPIVersion discovers the highest version of the
+// scheduling.k8s.io API group the connected cluster serves and caches it for
+// the lifetime of the clientset. PriorityClass went GA as `v1` in Kubernetes
+// 1.14; `v1beta1` and `v1alpha1` are kept around for older clusters and are
+// removed entirely in 1.25+ and 1.20+ respectively.
+func highestSchedulingAPIVersion(conn *kubernetes.Clientset) (schedulingAPIVersion, error) {
+	schedulingVersionCacheMu.Lock()
+	defer schedulingVersionCacheMu.Unlock()
+
+	if version, ok := schedulingVersionCache[conn]; ok {
+		return version, nil
+	}
+
+	group, err := conn.Discovery().ServerGroups()
+	if err != nil {
+		return "", fmt.Errorf("Failed to discover server groups: %s", err)
+	}
+
+	version, err := pickSchedulingAPIVersion(group.Groups)
+	if err != nil {
+		return "", err
+	}
+
+	schedulingVersionCache[conn] = version
+	return version, nil
+}
+
+// pickSchedulingAPIVersion is the pure selection logic behind
+// highestSchedulingAPIVersion, split out so it can be unit tested without a
+// real API server: given the API groups a cluster reports, it picks the
+// highest version of scheduling.k8s.io that is available.
+func pickSchedulingAPIVersion(groups []meta_v1.APIGroup) (schedulingAPIVersion, error) {
+	available := map[schedulingAPIVersion]bool{}
+	for _, g := range groups {
+		if g.Name != "scheduling.k8s.io" {
+			continue
+		}
+		for _, v := range g.Versions {
+			available[schedulingAPIVersion(v.Version)] = true
+		}
+	}
+
+	switch {
+	case available[schedulingV1]:
+		return schedulingV1, nil
+	case available[schedulingV1beta1]:
+		return schedulingV1beta1, nil
+	case available[schedulingV1alpha1]:
+		return schedulingV1alpha1, nil
+	default:
+		return "", fmt.Errorf("The connected cluster does not serve any known version of the scheduling.k8s.io API group")
+	}
+}
+
+// priorityClass is a version-agnostic representation of a PriorityClass that
+// the v1, v1beta1 and v1alpha1 APIs are converted to/from, so that the CRUD
+// logic in resource_kubernetes_resource_priority_class.go does not need to
+// know which API version it is actually talking to.
+type priorityClass struct {
+	ObjectMeta       meta_v1.ObjectMeta
+	Value            int32
+	GlobalDefault    bool
+	Description      string
+	PreemptionPolicy *string
+}
+
+func (p priorityClass) buildId() string {
+	return buildId(p.ObjectMeta)
+}
+
+func fromV1(in *api_v1.PriorityClass) *priorityClass {
+	p := &priorityClass{
+		ObjectMeta:    in.ObjectMeta,
+		Value:         in.Value,
+		GlobalDefault: in.GlobalDefault,
+		Description:   in.Description,
+	}
+	if in.PreemptionPolicy != nil {
+		policy := string(*in.PreemptionPolicy)
+		p.PreemptionPolicy = &policy
+	}
+	return p
+}
+
+func toV1(p priorityClass) *api_v1.PriorityClass {
+	out := &api_v1.PriorityClass{
+		ObjectMeta:    p.ObjectMeta,
+		Value:         p.Value,
+		GlobalDefault: p.GlobalDefault,
+		Description:   p.Description,
+	}
+	if p.PreemptionPolicy != nil {
+		policy := core_v1.PreemptionPolicy(*p.PreemptionPolicy)
+		out.PreemptionPolicy = &policy
+	}
+	return out
+}
+
+func fromV1beta1(in *api_v1beta1.PriorityClass) *priorityClass {
+	p := &priorityClass{
+		ObjectMeta:    in.ObjectMeta,
+		Value:         in.Value,
+		GlobalDefault: in.GlobalDefault,
+		Description:   in.Description,
+	}
+	if in.PreemptionPolicy != nil {
+		policy := string(*in.PreemptionPolicy)
+		p.PreemptionPolicy = &policy
+	}
+	return p
+}
+
+func toV1beta1(p priorityClass) *api_v1beta1.PriorityClass {
+	out := &api_v1beta1.PriorityClass{
+		ObjectMeta:    p.ObjectMeta,
+		Value:         p.Value,
+		GlobalDefault: p.GlobalDefault,
+		Description:   p.Description,
+	}
+	if p.PreemptionPolicy != nil {
+		policy := core_v1.PreemptionPolicy(*p.PreemptionPolicy)
+		out.PreemptionPolicy = &policy
+	}
+	return out
+}
+
+// fromV1alpha1 and toV1alpha1 intentionally drop PreemptionPolicy: the
+// scheduling.k8s.io/v1alpha1 PriorityClass predates that field entirely.
+func fromV1alpha1(in *api_v1alpha1.PriorityClass) *priorityClass {
+	return &priorityClass{
+		ObjectMeta:    in.ObjectMeta,
+		Value:         in.Value,
+		GlobalDefault: in.GlobalDefault,
+		Description:   in.Description,
+	}
+}
+
+func toV1alpha1(p priorityClass) *api_v1alpha1.PriorityClass {
+	return &api_v1alpha1.PriorityClass{
+		ObjectMeta:    p.ObjectMeta,
+		Value:         p.Value,
+		GlobalDefault: p.GlobalDefault,
+		Description:   p.Description,
+	}
+}
+
+func getPriorityClass(conn *kubernetes.Clientset, name string) (*priorityClass, error) {
+	version, err := highestSchedulingAPIVersion(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch version {
+	case schedulingV1:
+		out, err := conn.SchedulingV1().PriorityClasses().Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return fromV1(out), nil
+	case schedulingV1beta1:
+		out, err := conn.SchedulingV1beta1().PriorityClasses().Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return fromV1beta1(out), nil
+	default:
+		out, err := conn.SchedulingV1alpha1().PriorityClasses().Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return fromV1alpha1(out), nil
+	}
+}
+
+func createPriorityClass(conn *kubernetes.Clientset, p priorityClass) (*priorityClass, error) {
+	version, err := highestSchedulingAPIVersion(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch version {
+	case schedulingV1:
+		out, err := conn.SchedulingV1().PriorityClasses().Create(toV1(p))
+		if err != nil {
+			return nil, err
+		}
+		return fromV1(out), nil
+	case schedulingV1beta1:
+		out, err := conn.SchedulingV1beta1().PriorityClasses().Create(toV1beta1(p))
+		if err != nil {
+			return nil, err
+		}
+		return fromV1beta1(out), nil
+	default:
+		out, err := conn.SchedulingV1alpha1().PriorityClasses().Create(toV1alpha1(p))
+		if err != nil {
+			return nil, err
+		}
+		return fromV1alpha1(out), nil
+	}
+}
+
+func patchPriorityClass(conn *kubernetes.Clientset, name string, data []byte) (*priorityClass, error) {
+	version, err := highestSchedulingAPIVersion(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch version {
+	case schedulingV1:
+		out, err := conn.SchedulingV1().PriorityClasses().Patch(name, pkgApi.JSONPatchType, data)
+		if err != nil {
+			return nil, err
+		}
+		return fromV1(out), nil
+	case schedulingV1beta1:
+		out, err := conn.SchedulingV1beta1().PriorityClasses().Patch(name, pkgApi.JSONPatchType, data)
+		if err != nil {
+			return nil, err
+		}
+		return fromV1beta1(out), nil
+	default:
+		out, err := conn.SchedulingV1alpha1().PriorityClasses().Patch(name, pkgApi.JSONPatchType, data)
+		if err != nil {
+			return nil, err
+		}
+		return fromV1alpha1(out), nil
+	}
+}
+
+func deletePriorityClass(conn *kubernetes.Clientset, name string) error {
+	version, err := highestSchedulingAPIVersion(conn)
+	if err != nil {
+		return err
+	}
+
+	switch version {
+	case schedulingV1:
+		return conn.SchedulingV1().PriorityClasses().Delete(name, &meta_v1.DeleteOptions{})
+	case schedulingV1beta1:
+		return conn.SchedulingV1beta1().PriorityClasses().Delete(name, &meta_v1.DeleteOptions{})
+	default:
+		return conn.SchedulingV1alpha1().PriorityClasses().Delete(name, &meta_v1.DeleteOptions{})
+	}
+}
@@ -0,0 +1,147 @@
+package kubernetes
+
+import (
+	"testing"
+
+	core_v1 "k8s.io/api/core/v1"
+	api_v1 "k8s.io/api/scheduling/v1"
+	api_v1alpha1 "k8s.io/api/scheduling/v1alpha1"
+	api_v1beta1 "k8s.io/api/scheduling/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPickSchedulingAPIVersion(t *testing.T) {
+	cases := []struct {
+		name    string
+		groups  []meta_v1.APIGroup
+		want    schedulingAPIVersion
+		wantErr bool
+	}{
+		{
+			name: "prefers v1 when every version is served",
+			groups: []meta_v1.APIGroup{
+				{
+					Name: "scheduling.k8s.io",
+					Versions: []meta_v1.GroupVersionForDiscovery{
+						{Version: "v1alpha1"},
+						{Version: "v1beta1"},
+						{Version: "v1"},
+					},
+				},
+			},
+			want: schedulingV1,
+		},
+		{
+			name: "falls back to v1beta1 without v1",
+			groups: []meta_v1.APIGroup{
+				{
+					Name: "scheduling.k8s.io",
+					Versions: []meta_v1.GroupVersionForDiscovery{
+						{Version: "v1alpha1"},
+						{Version: "v1beta1"},
+					},
+				},
+			},
+			want: schedulingV1beta1,
+		},
+		{
+			name: "falls back to v1alpha1 when that's all there is",
+			groups: []meta_v1.APIGroup{
+				{
+					Name:     "scheduling.k8s.io",
+					Versions: []meta_v1.GroupVersionForDiscovery{{Version: "v1alpha1"}},
+				},
+			},
+			want: schedulingV1alpha1,
+		},
+		{
+			name:    "errors when the group is missing entirely",
+			groups:  []meta_v1.APIGroup{{Name: "apps"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := pickSchedulingAPIVersion(tc.groups)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got version %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected version %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPriorityClassV1RoundTrip(t *testing.T) {
+	policy := core_v1.PreemptNever
+	in := &api_v1.PriorityClass{
+		ObjectMeta:    meta_v1.ObjectMeta{Name: "high"},
+		Value:         1000,
+		GlobalDefault: true,
+		Description:   "a test priority class",
+		PreemptionPolicy: &policy,
+	}
+
+	p := fromV1(in)
+	if p.Value != in.Value || p.GlobalDefault != in.GlobalDefault || p.Description != in.Description {
+		t.Fatalf("fromV1 did not preserve scalar fields: %#v", p)
+	}
+	if p.PreemptionPolicy == nil || *p.PreemptionPolicy != string(policy) {
+		t.Fatalf("fromV1 did not preserve PreemptionPolicy: %#v", p.PreemptionPolicy)
+	}
+
+	out := toV1(*p)
+	if out.Value != in.Value || out.GlobalDefault != in.GlobalDefault || out.Description != in.Description {
+		t.Fatalf("toV1 did not preserve scalar fields: %#v", out)
+	}
+	if out.PreemptionPolicy == nil || *out.PreemptionPolicy != policy {
+		t.Fatalf("toV1 did not preserve PreemptionPolicy: %#v", out.PreemptionPolicy)
+	}
+}
+
+func TestPriorityClassV1beta1RoundTrip(t *testing.T) {
+	policy := core_v1.PreemptLowerPriority
+	in := &api_v1beta1.PriorityClass{
+		ObjectMeta:        meta_v1.ObjectMeta{Name: "low"},
+		Value:             10,
+		GlobalDefault:     false,
+		Description:       "another test priority class",
+		PreemptionPolicy:  &policy,
+	}
+
+	p := fromV1beta1(in)
+	out := toV1beta1(*p)
+	if out.Value != in.Value || out.GlobalDefault != in.GlobalDefault || out.Description != in.Description {
+		t.Fatalf("v1beta1 round trip did not preserve scalar fields: %#v", out)
+	}
+	if out.PreemptionPolicy == nil || *out.PreemptionPolicy != policy {
+		t.Fatalf("v1beta1 round trip did not preserve PreemptionPolicy: %#v", out.PreemptionPolicy)
+	}
+}
+
+func TestPriorityClassV1alpha1DropsPreemptionPolicy(t *testing.T) {
+	in := &api_v1alpha1.PriorityClass{
+		ObjectMeta:    meta_v1.ObjectMeta{Name: "legacy"},
+		Value:         5,
+		GlobalDefault: false,
+		Description:   "v1alpha1 predates preemptionPolicy",
+	}
+
+	p := fromV1alpha1(in)
+	if p.PreemptionPolicy != nil {
+		t.Fatalf("expected PreemptionPolicy to be nil from v1alpha1, got %#v", p.PreemptionPolicy)
+	}
+
+	policy := "Never"
+	p.PreemptionPolicy = &policy
+	out := toV1alpha1(*p)
+	_ = out // toV1alpha1 has no field to assign PreemptionPolicy to; this just documents the lossy conversion.
+}